@@ -1,6 +1,7 @@
 package linter
 
 import (
+	"flag"
 	"fmt"
 	"os"
 	"regexp"
@@ -14,6 +15,11 @@ import (
 	"github.com/skeema/tengo"
 )
 
+// keepContainers, set via -skeema.keep-containers, skips stopping
+// Dockerized test containers after the suite completes so they can be
+// inspected post-mortem.
+var keepContainers = flag.Bool("skeema.keep-containers", false, "Don't stop Dockerized test containers after the suite completes")
+
 func TestMain(m *testing.M) {
 	// Suppress packet error output when attempting to connect to a Dockerized
 	// mysql-server which is still starting up
@@ -22,6 +28,13 @@ func TestMain(m *testing.M) {
 	os.Exit(m.Run())
 }
 
+// TestIntegration runs the integration suite once per image in
+// SKEEMA_TEST_IMAGES, each against its own IntegrationSuite value (its own
+// d/schema/logicalSchema fields) and its own Dockerized container, started
+// concurrently rather than one at a time: every image's subtest calls
+// t.Parallel(), so once TestIntegration itself has fanned all of them out,
+// they run side by side instead of serially. Teardown is registered via
+// t.Cleanup so it still runs even if a subtest fails or panics.
 func TestIntegration(t *testing.T) {
 	images := tengo.SplitEnv("SKEEMA_TEST_IMAGES")
 	if len(images) == 0 {
@@ -31,10 +44,30 @@ func TestIntegration(t *testing.T) {
 	}
 	manager, err := tengo.NewDockerClient(tengo.DockerClientOptions{})
 	if err != nil {
-		t.Errorf("Unable to create sandbox manager: %s", err)
+		t.Fatalf("Unable to create sandbox manager: %s", err)
+	}
+
+	for _, image := range images {
+		image := image
+		t.Run(image, func(t *testing.T) {
+			t.Parallel()
+
+			suite := &IntegrationSuite{manager: manager}
+			if err := suite.Setup(image); err != nil {
+				t.Fatalf("Unable to set up Dockerized instance for %s: %s", image, err)
+			}
+			t.Cleanup(func() {
+				if err := suite.Teardown(image); err != nil {
+					t.Errorf("Unable to tear down Dockerized instance for %s: %s", image, err)
+				}
+			})
+
+			if err := suite.BeforeTest(image); err != nil {
+				t.Fatalf("Unable to reset schema state for %s: %s", image, err)
+			}
+			suite.TestCheckSchema(t)
+		})
 	}
-	suite := &IntegrationSuite{manager: manager}
-	tengo.RunSuite(suite, t, images)
 }
 
 type IntegrationSuite struct {
@@ -82,6 +115,9 @@ func (s *IntegrationSuite) Setup(backend string) (err error) {
 }
 
 func (s *IntegrationSuite) Teardown(backend string) error {
+	if *keepContainers {
+		return nil
+	}
 	return s.d.Stop()
 }
 
@@ -0,0 +1,190 @@
+package linter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// OutputFormat controls how a *Result is rendered by commands like
+// `skeema lint`.
+//
+// NOTE: this type and WriteJSON/WriteSARIF below are not yet wired up to an
+// actual --output-format CLI flag; `skeema lint` doesn't have a lint.go in
+// this tree to add one to. This is library-side plumbing only, pending the
+// CLI adapter.
+type OutputFormat string
+
+// Supported values for `skeema lint --output-format`.
+const (
+	OutputFormatText  OutputFormat = "text"
+	OutputFormatJSON  OutputFormat = "json"
+	OutputFormatSARIF OutputFormat = "sarif"
+)
+
+// ParseOutputFormat validates and normalizes the --output-format option
+// value. An empty string is treated as OutputFormatText.
+func ParseOutputFormat(value string) (OutputFormat, error) {
+	switch format := OutputFormat(strings.ToLower(strings.TrimSpace(value))); format {
+	case "", OutputFormatText:
+		return OutputFormatText, nil
+	case OutputFormatJSON, OutputFormatSARIF:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unsupported --output-format value %q", value)
+	}
+}
+
+// jsonAnnotation is the wire format for a single Annotation when
+// --output-format=json.
+type jsonAnnotation struct {
+	RuleName string `json:"ruleName"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+}
+
+// WriteJSON serializes result as a JSON array of annotations, one entry per
+// *Annotation, for ingestion by CI tooling that doesn't speak SARIF.
+func WriteJSON(result *Result, w io.Writer) error {
+	out := make([]jsonAnnotation, len(result.Annotations))
+	for i, a := range result.Annotations {
+		out[i] = jsonAnnotation{
+			RuleName: a.RuleName,
+			Severity: a.Severity.String(),
+			Message:  a.Message,
+			File:     a.Statement.File,
+			Line:     a.Statement.LineNo + a.Note.LineOffset,
+		}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+// SARIF 2.1.0 structures. Only the subset of the spec that skeema lint
+// results need is modeled here; see
+// https://docs.oasis-open.org/sarif/sarif/v2.1.0/ for the full schema.
+const (
+	sarifSchemaURI = "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json"
+	sarifVersion   = "2.1.0"
+)
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+// WriteSARIF serializes result as a SARIF 2.1.0 log. tool.driver.rules
+// enumerates every rule name present in opts.RuleSeverity, not just the ones
+// that produced an annotation, so that a rule with no findings still shows
+// up in dashboards such as GitHub code scanning.
+func WriteSARIF(result *Result, opts Options, w io.Writer) error {
+	ruleNames := make([]string, 0, len(opts.RuleSeverity))
+	for name := range opts.RuleSeverity {
+		ruleNames = append(ruleNames, name)
+	}
+	sort.Strings(ruleNames)
+
+	rules := make([]sarifRule, len(ruleNames))
+	for i, name := range ruleNames {
+		rules[i] = sarifRule{ID: name, Name: name}
+	}
+
+	results := make([]sarifResult, len(result.Annotations))
+	for i, a := range result.Annotations {
+		results[i] = sarifResult{
+			RuleID:  a.RuleName,
+			Level:   sarifLevel(a.Severity),
+			Message: sarifMessage{Text: a.Message},
+			Locations: []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: a.Statement.File},
+					Region:           sarifRegion{StartLine: a.Statement.LineNo + a.Note.LineOffset},
+				},
+			}},
+		}
+	}
+
+	log := sarifLog{
+		Schema:  sarifSchemaURI,
+		Version: sarifVersion,
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           "skeema",
+				InformationURI: "https://www.skeema.io",
+				Rules:          rules,
+			}},
+			Results: results,
+		}},
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(log)
+}
+
+// sarifLevel maps a linter Severity to the SARIF result.level vocabulary
+// ("error", "warning", or "note").
+func sarifLevel(s Severity) string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	default:
+		return "note"
+	}
+}
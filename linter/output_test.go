@@ -0,0 +1,181 @@
+package linter
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/skeema/skeema/fs"
+)
+
+func TestParseOutputFormat(t *testing.T) {
+	cases := map[string]OutputFormat{
+		"":      OutputFormatText,
+		"text":  OutputFormatText,
+		"JSON":  OutputFormatJSON,
+		"sarif": OutputFormatSARIF,
+	}
+	for input, expected := range cases {
+		actual, err := ParseOutputFormat(input)
+		if err != nil {
+			t.Errorf("ParseOutputFormat(%q): unexpected error %v", input, err)
+		} else if actual != expected {
+			t.Errorf("ParseOutputFormat(%q): expected %q, found %q", input, expected, actual)
+		}
+	}
+
+	if _, err := ParseOutputFormat("yaml"); err == nil {
+		t.Error("Expected error from ParseOutputFormat(\"yaml\"), instead found nil")
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	result := &Result{
+		Annotations: []*Annotation{
+			{
+				RuleName:  "no-drop",
+				Severity:  SeverityError,
+				Message:   "DROP not permitted",
+				Statement: &fs.Statement{File: "schemas/prod/product.sql", LineNo: 10},
+				Note:      Note{LineOffset: 2},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteJSON(result, &buf); err != nil {
+		t.Fatalf("Unexpected error from WriteJSON: %v", err)
+	}
+
+	var out []jsonAnnotation
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("Output was not valid JSON: %v", err)
+	}
+	if len(out) != 1 {
+		t.Fatalf("Expected 1 annotation, found %d", len(out))
+	}
+	if out[0].Line != 12 {
+		t.Errorf("Expected line 12 (LineNo + Note.LineOffset), found %d", out[0].Line)
+	}
+	if out[0].Severity != "error" {
+		t.Errorf("Expected severity \"error\", found %q", out[0].Severity)
+	}
+}
+
+// sarifLogSchema models the subset of the published SARIF 2.1.0 schema
+// (https://docs.oasis-open.org/sarif/sarif/v2.1.0/) that's required for a
+// minimally-conformant log, independent of the sarif* types in output.go, so
+// that this test actually validates shape rather than round-tripping through
+// the same structs that produced the output.
+type sarifLogSchema struct {
+	Schema  string `json:"$schema"`
+	Version string `json:"version"`
+	Runs    []struct {
+		Tool struct {
+			Driver struct {
+				Name  string `json:"name"`
+				Rules []struct {
+					ID string `json:"id"`
+				} `json:"rules"`
+			} `json:"driver"`
+		} `json:"tool"`
+		Results []struct {
+			RuleID  string `json:"ruleId"`
+			Level   string `json:"level"`
+			Message struct {
+				Text string `json:"text"`
+			} `json:"message"`
+			Locations []struct {
+				PhysicalLocation struct {
+					ArtifactLocation struct {
+						URI string `json:"uri"`
+					} `json:"artifactLocation"`
+					Region struct {
+						StartLine int `json:"startLine"`
+					} `json:"region"`
+				} `json:"physicalLocation"`
+			} `json:"locations"`
+		} `json:"results"`
+	} `json:"runs"`
+}
+
+func TestWriteSARIF(t *testing.T) {
+	opts := Options{
+		RuleSeverity: map[string]Severity{
+			"no-drop":       SeverityError,
+			"display-width": SeverityIgnore,
+		},
+	}
+	result := &Result{
+		Annotations: []*Annotation{
+			{
+				RuleName:  "no-drop",
+				Severity:  SeverityError,
+				Message:   "DROP not permitted",
+				Statement: &fs.Statement{File: "schemas/prod/product.sql", LineNo: 10},
+				Note:      Note{LineOffset: 2},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteSARIF(result, opts, &buf); err != nil {
+		t.Fatalf("Unexpected error from WriteSARIF: %v", err)
+	}
+
+	var doc sarifLogSchema
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("Output was not valid JSON, or did not match the expected SARIF shape: %v", err)
+	}
+
+	// Required per the SARIF 2.1.0 spec: version, and at least one run with
+	// a named tool driver.
+	if doc.Version != "2.1.0" {
+		t.Errorf("Expected version \"2.1.0\", found %q", doc.Version)
+	}
+	if len(doc.Runs) != 1 {
+		t.Fatalf("Expected exactly 1 run, found %d", len(doc.Runs))
+	}
+	run := doc.Runs[0]
+	if run.Tool.Driver.Name == "" {
+		t.Error("Expected tool.driver.name to be set")
+	}
+
+	ruleIDs := make(map[string]bool)
+	for _, rule := range run.Tool.Driver.Rules {
+		if rule.ID == "" {
+			t.Error("Expected every tool.driver.rules[] entry to have a non-empty id")
+		}
+		ruleIDs[rule.ID] = true
+	}
+	if !ruleIDs["display-width"] {
+		t.Error("Expected unused rule \"display-width\" to still appear in tool.driver.rules")
+	}
+	if !ruleIDs["no-drop"] {
+		t.Error("Expected rule \"no-drop\" to appear in tool.driver.rules")
+	}
+
+	if len(run.Results) != 1 {
+		t.Fatalf("Expected exactly 1 result, found %d", len(run.Results))
+	}
+	res := run.Results[0]
+	if res.RuleID != "no-drop" {
+		t.Errorf("Expected result ruleId \"no-drop\", found %q", res.RuleID)
+	}
+	if res.Level != "error" {
+		t.Errorf("Expected result level \"error\", found %q", res.Level)
+	}
+	if res.Message.Text == "" {
+		t.Error("Expected result message.text to be set, per the SARIF spec requirement that message is non-empty")
+	}
+	if len(res.Locations) != 1 {
+		t.Fatalf("Expected exactly 1 location, found %d", len(res.Locations))
+	}
+	loc := res.Locations[0].PhysicalLocation
+	if loc.ArtifactLocation.URI != "schemas/prod/product.sql" {
+		t.Errorf("Expected artifactLocation.uri \"schemas/prod/product.sql\", found %q", loc.ArtifactLocation.URI)
+	}
+	if loc.Region.StartLine != 12 {
+		t.Errorf("Expected region.startLine 12 (LineNo + Note.LineOffset), found %d", loc.Region.StartLine)
+	}
+}
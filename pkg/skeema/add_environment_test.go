@@ -0,0 +1,196 @@
+package skeema
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/skeema/mybase"
+	"github.com/skeema/skeema/fs"
+	"github.com/skeema/skeema/util"
+	"github.com/skeema/tengo"
+)
+
+// testDir copies the named fixture under testdata into a fresh temp dir and
+// parses it into an *fs.Dir, so that each test gets its own option file to
+// mutate rather than sharing (and dirtying) the committed fixture.
+func testDir(t *testing.T, fixture string) *fs.Dir {
+	t.Helper()
+
+	tmpDir := t.TempDir()
+	if fixture != "" {
+		src, err := os.ReadFile(filepath.Join("testdata", fixture, ".skeema"))
+		if err != nil {
+			t.Fatalf("Unable to read fixture %s: %v", fixture, err)
+		}
+		if err := os.WriteFile(filepath.Join(tmpDir, ".skeema"), src, 0644); err != nil {
+			t.Fatalf("Unable to write fixture %s to temp dir: %v", fixture, err)
+		}
+	}
+
+	cmd := mybase.NewCommand("skeematest", "", "", nil)
+	util.AddGlobalOptions(cmd)
+	cmd.AddArg("environment", "production", false)
+	cfg := mybase.ParseFakeCLI(t, cmd, "skeematest")
+
+	dir, err := fs.ParseDir(tmpDir, cfg)
+	if err != nil {
+		t.Fatalf("Unexpected error parsing dir %s: %s", tmpDir, err)
+	}
+	return dir
+}
+
+func TestAddEnvironment(t *testing.T) {
+	dir := testDir(t, "hostdir")
+	deps := Dependencies{
+		InstanceForDir: func(*fs.Dir) (*tengo.Instance, error) {
+			return &tengo.Instance{Host: "staging.example.com", Port: 3307}, nil
+		},
+	}
+	in := AddEnvironmentInput{
+		Dir:         dir,
+		Environment: "staging",
+		HostOnCLI:   true,
+		UserOnCLI:   true,
+		User:        "appuser",
+	}
+
+	result, err := AddEnvironment(in, deps)
+	if err != nil {
+		t.Fatalf("Unexpected error from AddEnvironment: %v", err)
+	}
+	if result.Environment != "staging" {
+		t.Errorf("Expected result.Environment \"staging\", found %q", result.Environment)
+	}
+
+	hostOptionFile, err := dir.OptionFile()
+	if err != nil {
+		t.Fatalf("Unexpected error re-reading option file: %v", err)
+	}
+	if !hostOptionFile.HasSection("staging") {
+		t.Error("Expected option file to have a [staging] section after AddEnvironment, but it did not")
+	}
+}
+
+// TestAddEnvironmentDSNFallsBackToCLIUser confirms that --user is still
+// honored when a --dsn is supplied but the DSN itself has no embedded user,
+// e.g. a host/TLS-only connection string pulled from a secret manager.
+func TestAddEnvironmentDSNFallsBackToCLIUser(t *testing.T) {
+	dir := testDir(t, "hostdir")
+	in := AddEnvironmentInput{
+		Dir:         dir,
+		Environment: "staging",
+		DSN:         "mysql://staging.example.com:3307/?tls=preferred",
+		UserOnCLI:   true,
+		User:        "appuser",
+	}
+	deps := Dependencies{
+		InstanceForConnection: func(ConnectionParams) (*tengo.Instance, error) {
+			return &tengo.Instance{Host: "staging.example.com", Port: 3307}, nil
+		},
+	}
+
+	if _, err := AddEnvironment(in, deps); err != nil {
+		t.Fatalf("Unexpected error from AddEnvironment: %v", err)
+	}
+
+	hostOptionFile, err := dir.OptionFile()
+	if err != nil {
+		t.Fatalf("Unexpected error re-reading option file: %v", err)
+	}
+	if got := hostOptionFile.OptionValue("staging", "user"); got != "appuser" {
+		t.Errorf("Expected [staging] user to be \"appuser\", found %q", got)
+	}
+}
+
+// TestAddEnvironmentDSNValidatesConnection confirms that a --dsn whose
+// host/port can't actually be connected to is rejected, the same way an
+// unreachable --host is, instead of being written to the .skeema file
+// unvalidated.
+func TestAddEnvironmentDSNValidatesConnection(t *testing.T) {
+	dir := testDir(t, "hostdir")
+	in := AddEnvironmentInput{
+		Dir:         dir,
+		Environment: "staging",
+		DSN:         "mysql://unreachable.example.invalid:3307/",
+	}
+	deps := Dependencies{
+		InstanceForConnection: func(ConnectionParams) (*tengo.Instance, error) {
+			return nil, errors.New("connection refused")
+		},
+	}
+
+	if _, err := AddEnvironment(in, deps); err == nil {
+		t.Error("Expected error from AddEnvironment with an unreachable --dsn, instead found nil")
+	}
+}
+
+func TestAddEnvironmentErrors(t *testing.T) {
+	okDeps := Dependencies{
+		InstanceForDir: func(*fs.Dir) (*tengo.Instance, error) {
+			return &tengo.Instance{Host: "staging.example.com", Port: 3307}, nil
+		},
+	}
+
+	tests := []struct {
+		name string
+		dir  *fs.Dir
+		in   AddEnvironmentInput
+		deps Dependencies
+	}{
+		{
+			name: "no option file",
+			dir:  testDir(t, ""),
+			in:   AddEnvironmentInput{Environment: "staging", HostOnCLI: true},
+			deps: okDeps,
+		},
+		{
+			name: "invalid environment name",
+			dir:  testDir(t, "hostdir"),
+			in:   AddEnvironmentInput{Environment: "bad[name]", HostOnCLI: true},
+			deps: okDeps,
+		},
+		{
+			name: "duplicate environment name",
+			dir:  testDir(t, "hostdir"),
+			in:   AddEnvironmentInput{Environment: "production", HostOnCLI: true},
+			deps: okDeps,
+		},
+		{
+			name: "no host defined in existing dir",
+			dir:  testDir(t, "nohostdir"),
+			in:   AddEnvironmentInput{Environment: "staging", HostOnCLI: true},
+			deps: okDeps,
+		},
+		{
+			name: "missing --host and --dsn",
+			dir:  testDir(t, "hostdir"),
+			in:   AddEnvironmentInput{Environment: "staging"},
+			deps: okDeps,
+		},
+		{
+			name: "instance lookup failure",
+			dir:  testDir(t, "hostdir"),
+			in:   AddEnvironmentInput{Environment: "staging", HostOnCLI: true},
+			deps: Dependencies{InstanceForDir: func(*fs.Dir) (*tengo.Instance, error) {
+				return nil, errors.New("connection refused")
+			}},
+		},
+		{
+			name: "nil instance with no error",
+			dir:  testDir(t, "hostdir"),
+			in:   AddEnvironmentInput{Environment: "staging", HostOnCLI: true},
+			deps: Dependencies{InstanceForDir: func(*fs.Dir) (*tengo.Instance, error) {
+				return nil, nil
+			}},
+		},
+	}
+
+	for _, test := range tests {
+		test.in.Dir = test.dir
+		if _, err := AddEnvironment(test.in, test.deps); err == nil {
+			t.Errorf("%s: expected error, instead found nil", test.name)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+// Package skeema exposes skeema's command logic as a reusable, embeddable
+// Go library, independent of the CLI in package main. Each exported
+// function here corresponds to a skeema subcommand: it takes a typed input
+// struct instead of a *mycli.Config, accepts a Dependencies value for
+// anything that talks to the outside world (logging, stdout, connecting to
+// instances), and returns a typed result. Any human-readable output goes to
+// Dependencies.Stdout rather than os.Stdout, and nothing here calls os.Exit.
+// Package main adapts CLI flags into these inputs and
+// renders the results; other Go programs can import this package directly
+// to embed skeema's command logic without shelling out.
+//
+// Only add-environment (AddEnvironment) has been extracted so far. init,
+// pull, push, diff, and lint still have their core logic in package main
+// and have not yet been moved here.
+package skeema
+
+import (
+	"fmt"
+	"io"
+	"log"
+
+	"github.com/skeema/skeema/fs"
+	"github.com/skeema/tengo"
+)
+
+// Dependencies bundles the side-effecting collaborators that command logic
+// in this package needs, so that embedders (and tests) can substitute their
+// own logger, stdout destination, or instance connector instead of being
+// stuck with the CLI's defaults.
+type Dependencies struct {
+	// Logger receives diagnostic output. If nil, log.Default() is used.
+	Logger *log.Logger
+
+	// Stdout receives human-readable command output. If nil, output is
+	// discarded.
+	Stdout io.Writer
+
+	// InstanceForDir connects to the instance represented by dir and
+	// returns it. If nil, dir.FirstInstance is used.
+	InstanceForDir func(dir *fs.Dir) (*tengo.Instance, error)
+
+	// InstanceForConnection connects to the instance described by params
+	// (as parsed from a --dsn) and returns it, so DSN-derived connection
+	// info gets the same validate-before-write treatment as InstanceForDir.
+	// If nil, tengo.NewInstance is used with the "mysql" driver, and the
+	// instance is checked with CanConnect before being returned.
+	InstanceForConnection func(params ConnectionParams) (*tengo.Instance, error)
+}
+
+func (d Dependencies) logger() *log.Logger {
+	if d.Logger != nil {
+		return d.Logger
+	}
+	return log.Default()
+}
+
+func (d Dependencies) stdout() io.Writer {
+	if d.Stdout != nil {
+		return d.Stdout
+	}
+	return io.Discard
+}
+
+func (d Dependencies) instanceForDir(dir *fs.Dir) (*tengo.Instance, error) {
+	if d.InstanceForDir != nil {
+		return d.InstanceForDir(dir)
+	}
+	return dir.FirstInstance()
+}
+
+func (d Dependencies) instanceForConnection(params ConnectionParams) (*tengo.Instance, error) {
+	if d.InstanceForConnection != nil {
+		return d.InstanceForConnection(params)
+	}
+	addr := params.Host
+	if params.Port != 0 {
+		addr = fmt.Sprintf("%s:%d", params.Host, params.Port)
+	}
+	dsn := fmt.Sprintf("%s@tcp(%s)/", params.User, addr)
+	inst, err := tengo.NewInstance("mysql", dsn)
+	if err != nil {
+		return nil, err
+	}
+	if ok, err := inst.CanConnect(); !ok {
+		return nil, err
+	}
+	return inst, nil
+}
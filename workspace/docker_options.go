@@ -0,0 +1,202 @@
+package workspace
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// DockerContainerOptions is a structured, docker-create-shaped
+// representation of extra `docker create` flags to apply when creating a
+// workspace (or integration test) container, on top of the fixed set of
+// parameters DockerizedInstanceOptions already exposes (name, image, root
+// password, default connection params). The handful of flags that matter
+// most for workspace containers -- resource limits, tmpfs mounts,
+// networking, and security -- get their own typed fields; anything else is
+// preserved verbatim in Extra, so arbitrary `docker create` flags are still
+// supported even though they aren't individually modeled here.
+type DockerContainerOptions struct {
+	Memory      string
+	CPUs        string
+	Network     string
+	Tmpfs       []string
+	Ulimit      []string
+	Volume      []string
+	Sysctl      map[string]string
+	SecurityOpt []string
+
+	// Extra holds every flag that has no dedicated field above, whatever its
+	// name (names are not checked against docker create's own flag list),
+	// each occurrence formatted as "--name=value" (or bare "--name" for a
+	// valueless flag), in the order they appeared. Callers building the
+	// final docker create invocation (or an equivalent container.HostConfig)
+	// should append these verbatim.
+	Extra []string
+}
+
+// dockerValueFlags lists the docker create flags this parser populates a
+// dedicated struct field for. Unlike unrecognized flags (see dockerBooleanFlags
+// and the lookahead in ParseDockerContainerOptions below), these always
+// require an explicit value, so a missing one is a hard parse error rather
+// than being treated as a valueless flag.
+var dockerValueFlags = map[string]bool{
+	"memory":       true,
+	"cpus":         true,
+	"network":      true,
+	"tmpfs":        true,
+	"ulimit":       true,
+	"volume":       true,
+	"sysctl":       true,
+	"security-opt": true,
+}
+
+// dockerBooleanFlags lists common valueless (boolean) docker create flags,
+// which never consume the following token as their value, even when that
+// token does not begin with -- (e.g. "--privileged host" is rejected rather
+// than parsed as --privileged=host).
+var dockerBooleanFlags = map[string]bool{
+	"detach":           true,
+	"init":             true,
+	"interactive":      true,
+	"no-healthcheck":   true,
+	"oom-kill-disable": true,
+	"privileged":       true,
+	"publish-all":      true,
+	"quiet":            true,
+	"read-only":        true,
+	"rm":               true,
+	"tty":              true,
+}
+
+// ParseDockerContainerOptions parses a string of space-separated
+// `docker create`-style flags -- as supplied via --docker-options or the
+// SKEEMA_TEST_DOCKER_OPTIONS env var -- into a DockerContainerOptions.
+// Flags may be written as `--name value`, `--name=value`, or (for a
+// valueless/boolean flag) bare `--name`. The repeatable flags (tmpfs,
+// ulimit, volume, sysctl, security-opt) may appear more than once and
+// accumulate; memory, cpus, and network keep the last value seen. Any flag not
+// individually modeled above is accumulated into Extra rather than
+// rejected, so arbitrary docker create flags (--add-host, --cap-add,
+// --label, --pid, etc.) can still be passed through.
+func ParseDockerContainerOptions(raw string) (*DockerContainerOptions, error) {
+	opts := &DockerContainerOptions{Sysctl: make(map[string]string)}
+
+	tokens, err := splitDockerOptionArgs(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		if !strings.HasPrefix(token, "--") {
+			return nil, fmt.Errorf("docker option %q does not begin with --", token)
+		}
+		name, value, hasValue := strings.Cut(strings.TrimPrefix(token, "--"), "=")
+
+		if !hasValue {
+			// A bare flag at the end of the string, or immediately followed by
+			// another flag, has no value to consume -- unless it's one of the
+			// flags we know always requires one, in which case that's an error
+			// rather than silently treating it as boolean.
+			nextIsFlag := i+1 >= len(tokens) || strings.HasPrefix(tokens[i+1], "--")
+			switch {
+			case dockerBooleanFlags[name]:
+				// valueless
+			case nextIsFlag:
+				if dockerValueFlags[name] {
+					return nil, fmt.Errorf("docker option --%s is missing a value", name)
+				}
+				// An unrecognized flag with nothing that looks like a value
+				// after it; treat it as valueless too (e.g. --rm, --tty spelled
+				// under a name we don't have in dockerBooleanFlags).
+			default:
+				i++
+				value = tokens[i]
+				hasValue = true
+			}
+		}
+
+		switch name {
+		case "memory":
+			opts.Memory = value
+		case "cpus":
+			opts.CPUs = value
+		case "network":
+			opts.Network = value
+		case "tmpfs":
+			opts.Tmpfs = append(opts.Tmpfs, value)
+		case "ulimit":
+			opts.Ulimit = append(opts.Ulimit, value)
+		case "volume":
+			opts.Volume = append(opts.Volume, value)
+		case "security-opt":
+			opts.SecurityOpt = append(opts.SecurityOpt, value)
+		case "sysctl":
+			key, val, ok := strings.Cut(value, "=")
+			if !ok {
+				return nil, fmt.Errorf("docker option --sysctl value %q must be of the form key=value", value)
+			}
+			opts.Sysctl[key] = val
+		default:
+			if hasValue {
+				opts.Extra = append(opts.Extra, fmt.Sprintf("--%s=%s", name, value))
+			} else {
+				opts.Extra = append(opts.Extra, fmt.Sprintf("--%s", name))
+			}
+		}
+	}
+	return opts, nil
+}
+
+// DockerContainerOptionsFromEnv parses SKEEMA_TEST_DOCKER_OPTIONS, returning
+// nil (with no error) if it is unset, so that test harnesses can treat an
+// unset env var as "no extra options" without a separate existence check.
+func DockerContainerOptionsFromEnv() (*DockerContainerOptions, error) {
+	raw := os.Getenv("SKEEMA_TEST_DOCKER_OPTIONS")
+	if raw == "" {
+		return nil, nil
+	}
+	return ParseDockerContainerOptions(raw)
+}
+
+// splitDockerOptionArgs tokenizes raw on whitespace, honoring single- and
+// double-quoted substrings so that values like --volume "/host path:/ctr"
+// survive intact.
+func splitDockerOptionArgs(raw string) ([]string, error) {
+	var tokens []string
+	var cur strings.Builder
+	var quote rune
+	inToken := false
+
+	flush := func() {
+		if inToken {
+			tokens = append(tokens, cur.String())
+			cur.Reset()
+			inToken = false
+		}
+	}
+
+	for _, r := range raw {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				cur.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inToken = true
+		case r == ' ' || r == '\t' || r == '\n':
+			flush()
+		default:
+			inToken = true
+			cur.WriteRune(r)
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote in docker options %q", raw)
+	}
+	flush()
+	return tokens, nil
+}
@@ -0,0 +1,100 @@
+package workspace
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseDockerContainerOptions(t *testing.T) {
+	raw := `--memory 512m --cpus 2 --network skeema-net --tmpfs /var/lib/mysql --tmpfs /tmp ` +
+		`--ulimit nofile=1024:1024 --volume /host/data:/var/lib/mysql-extra --sysctl net.core.somaxconn=1024 ` +
+		`--security-opt seccomp=unconfined`
+
+	opts, err := ParseDockerContainerOptions(raw)
+	if err != nil {
+		t.Fatalf("Unexpected error from ParseDockerContainerOptions: %v", err)
+	}
+
+	expected := &DockerContainerOptions{
+		Memory:      "512m",
+		CPUs:        "2",
+		Network:     "skeema-net",
+		Tmpfs:       []string{"/var/lib/mysql", "/tmp"},
+		Ulimit:      []string{"nofile=1024:1024"},
+		Volume:      []string{"/host/data:/var/lib/mysql-extra"},
+		Sysctl:      map[string]string{"net.core.somaxconn": "1024"},
+		SecurityOpt: []string{"seccomp=unconfined"},
+	}
+	if !reflect.DeepEqual(opts, expected) {
+		t.Errorf("Parsed options did not match expectation.\nExpected: %+v\nFound:    %+v", expected, opts)
+	}
+}
+
+func TestParseDockerContainerOptionsEqualsForm(t *testing.T) {
+	opts, err := ParseDockerContainerOptions(`--memory=1g --volume="/host path:/ctr path"`)
+	if err != nil {
+		t.Fatalf("Unexpected error from ParseDockerContainerOptions: %v", err)
+	}
+	if opts.Memory != "1g" {
+		t.Errorf("Expected Memory \"1g\", found %q", opts.Memory)
+	}
+	if len(opts.Volume) != 1 || opts.Volume[0] != "/host path:/ctr path" {
+		t.Errorf("Expected a single quoted volume value, found %v", opts.Volume)
+	}
+}
+
+// TestParseDockerContainerOptionsArbitraryFlag confirms that a docker create
+// flag with no dedicated struct field (e.g. --add-host) is preserved
+// verbatim in Extra rather than rejected, since --docker-options is meant to
+// support arbitrary docker create flags.
+func TestParseDockerContainerOptionsArbitraryFlag(t *testing.T) {
+	opts, err := ParseDockerContainerOptions(`--memory 512m --add-host db.internal:10.0.0.5 --label owner=skeema`)
+	if err != nil {
+		t.Fatalf("Unexpected error from ParseDockerContainerOptions: %v", err)
+	}
+	expected := []string{"--add-host=db.internal:10.0.0.5", "--label=owner=skeema"}
+	if len(opts.Extra) != len(expected) {
+		t.Fatalf("Expected Extra %v, found %v", expected, opts.Extra)
+	}
+	for i := range expected {
+		if opts.Extra[i] != expected[i] {
+			t.Errorf("Expected Extra[%d] = %q, found %q", i, expected[i], opts.Extra[i])
+		}
+	}
+}
+
+// TestParseDockerContainerOptionsValuelessFlags confirms that boolean
+// docker create flags don't swallow the following flag as their value, and
+// that a trailing bare boolean flag doesn't error as "missing a value".
+func TestParseDockerContainerOptionsValuelessFlags(t *testing.T) {
+	opts, err := ParseDockerContainerOptions(`--privileged --network host --rm`)
+	if err != nil {
+		t.Fatalf("Unexpected error from ParseDockerContainerOptions: %v", err)
+	}
+	if opts.Network != "host" {
+		t.Errorf("Expected Network \"host\" (not swallowed by --privileged), found %q", opts.Network)
+	}
+	expectedExtra := []string{"--privileged", "--rm"}
+	if len(opts.Extra) != len(expectedExtra) {
+		t.Fatalf("Expected Extra %v, found %v", expectedExtra, opts.Extra)
+	}
+	for i := range expectedExtra {
+		if opts.Extra[i] != expectedExtra[i] {
+			t.Errorf("Expected Extra[%d] = %q, found %q", i, expectedExtra[i], opts.Extra[i])
+		}
+	}
+}
+
+func TestParseDockerContainerOptionsErrors(t *testing.T) {
+	badInputs := []string{
+		"memory 512m",           // missing leading --
+		"--memory",              // missing value
+		"--sysctl net.core",     // sysctl without key=value
+		`--volume "unterminated`, // unterminated quote
+	}
+	for _, input := range badInputs {
+		if _, err := ParseDockerContainerOptions(input); err == nil {
+			t.Errorf("Expected error for input %q, instead found nil", input)
+		}
+	}
+}